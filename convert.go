@@ -0,0 +1,199 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownProtocol is returned when a Signal names a Protocol this
+// package has no built-in timing table for, so it can't be synthesized to
+// or decoded from its raw pulse train.
+var ErrUnknownProtocol = errors.New("flipperirfile: unknown protocol")
+
+// jitterTolerance is how far a decoded pulse may drift from its expected
+// duration, as a fraction of that duration, and still be accepted.
+const jitterTolerance = 0.25
+
+// ToRaw synthesizes s's raw pulse train from its Protocol, Address and
+// Command using the package's built-in timing tables, and returns it as a
+// new raw Signal. s itself is left untouched.
+//
+// It returns ErrUnknownProtocol if s.Protocol isn't recognized.
+func (s *Signal) ToRaw() (*Signal, error) {
+	if s.Type != SignalTypeParsed {
+		return nil, fmt.Errorf("flipperirfile: ToRaw: signal %q is not a parsed signal", s.Name)
+	}
+
+	t, ok := timingFor(Protocol(s.Protocol))
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProtocol, s.Protocol)
+	}
+
+	return &Signal{
+		Name:      s.Name,
+		Type:      SignalTypeRaw,
+		Frequency: t.Carrier,
+		DutyCycle: t.DutyCycle,
+		Data:      synthesizePulses(s, t),
+	}, nil
+}
+
+// ToParsed decodes s's raw pulse train against the package's built-in
+// timing tables and returns the result as a new parsed Signal. Pulses may
+// drift up to jitterTolerance from their expected duration and still be
+// accepted. When more than one protocol's timing matches the pulse train
+// (a shorter protocol is often a valid prefix of a longer, related one),
+// the protocol that accounts for the most of the pulse train wins. s
+// itself is left untouched.
+//
+// It returns ErrUnknownProtocol if no known protocol matches the pulse
+// train, so callers can fall back to keeping the signal raw.
+func (s *Signal) ToParsed() (*Signal, error) {
+	if s.Type != SignalTypeRaw {
+		return nil, fmt.Errorf("flipperirfile: ToParsed: signal %q is not a raw signal", s.Name)
+	}
+
+	var (
+		best     Protocol
+		bestAddr uint32
+		bestCmd  uint32
+		bestPos  int
+		found    bool
+	)
+
+	for p, t := range protocolTimings {
+		addr, cmd, pos, ok := decodePulses(s.Data, t)
+		if !ok || pos <= bestPos {
+			continue
+		}
+
+		best, bestAddr, bestCmd, bestPos, found = p, addr, cmd, pos, true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no known protocol matches this pulse train", ErrUnknownProtocol)
+	}
+
+	return &Signal{
+		Name:     s.Name,
+		Type:     SignalTypeParsed,
+		Protocol: string(best),
+		Address:  bestAddr,
+		Command:  bestCmd,
+	}, nil
+}
+
+// decodePulses attempts to decode data as a single frame of protocol
+// timing t, returning the decoded address and command, the number of
+// pulses consumed, and whether it succeeded.
+func decodePulses(data []int, t protocolTiming) (addr, cmd uint32, pos int, ok bool) { //nolint:cyclop
+	withinTolerance := func(got, want int) bool {
+		lo := float64(want) * (1 - jitterTolerance)
+		hi := float64(want) * (1 + jitterTolerance)
+
+		return float64(got) >= lo && float64(got) <= hi
+	}
+
+	if t.LeaderMark > 0 {
+		if pos+1 >= len(data) || !withinTolerance(data[pos], t.LeaderMark) || !withinTolerance(data[pos+1], t.LeaderSpace) {
+			return 0, 0, 0, false
+		}
+
+		pos += 2
+	}
+
+	readField := func(bytes int) (uint32, bool) {
+		bits := bytes * 8
+
+		v, n, ok := decodeBits(data[pos:], t, bits, withinTolerance)
+		if !ok {
+			return 0, false
+		}
+
+		pos += n
+
+		if t.Inverted {
+			inv, n2, ok := decodeBits(data[pos:], t, bits, withinTolerance)
+			if !ok || inv != ^v&mask(bits) {
+				return 0, false
+			}
+
+			pos += n2
+		}
+
+		return v, true
+	}
+
+	addr, ok = readField(t.AddressBytes)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	cmd, ok = readField(t.CommandBytes)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	return addr, cmd, pos, true
+}
+
+// decodeBits reads bits-worth of BitMark/ZeroSpace|OneSpace pairs off the
+// front of data and returns the decoded value and the number of pulses
+// consumed.
+func decodeBits(data []int, t protocolTiming, bits int, withinTolerance func(got, want int) bool) (uint32, int, bool) {
+	if len(data) < bits*2 {
+		return 0, 0, false
+	}
+
+	var v uint32
+
+	for i := 0; i < bits; i++ {
+		mark, space := data[i*2], data[i*2+1]
+		if !withinTolerance(mark, t.BitMark) {
+			return 0, 0, false
+		}
+
+		var bit uint32
+
+		switch {
+		case withinTolerance(space, t.OneSpace):
+			bit = 1
+		case withinTolerance(space, t.ZeroSpace):
+			bit = 0
+		default:
+			return 0, 0, false
+		}
+
+		idx := i
+		if !t.LSBFirst {
+			idx = bits - 1 - i
+		}
+
+		v |= bit << idx
+	}
+
+	return v, bits * 2, true
+}
+
+// mask returns a bitmask with the low `bits` bits set.
+func mask(bits int) uint32 {
+	if bits >= 32 {
+		return ^uint32(0)
+	}
+
+	return 1<<uint(bits) - 1
+}
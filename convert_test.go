@@ -0,0 +1,63 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import "testing"
+
+func TestToRawValidForEveryProtocol(t *testing.T) {
+	for p := range protocolTimings {
+		s := &Signal{Name: "test", Type: SignalTypeParsed, Protocol: string(p), Address: 1, Command: 2}
+
+		raw, err := s.ToRaw()
+		if err != nil {
+			t.Errorf("%s: ToRaw returned error: %v", p, err)
+			continue
+		}
+
+		if err := raw.Validate(); err != nil {
+			t.Errorf("%s: ToRaw's output failed Validate: %v", p, err)
+		}
+	}
+}
+
+func TestToParsedRoundTrip(t *testing.T) {
+	for p := range protocolTimings {
+		s := &Signal{Name: "test", Type: SignalTypeParsed, Protocol: string(p), Address: 1, Command: 2}
+
+		raw, err := s.ToRaw()
+		if err != nil {
+			t.Errorf("%s: ToRaw returned error: %v", p, err)
+			continue
+		}
+
+		parsed, err := raw.ToParsed()
+		if err != nil {
+			t.Errorf("%s: ToParsed returned error: %v", p, err)
+			continue
+		}
+
+		if parsed.Protocol != string(p) {
+			t.Errorf("%s: protocol = %q, want %q", p, parsed.Protocol, p)
+		}
+
+		if parsed.Address != s.Address {
+			t.Errorf("%s: address = %#x, want %#x", p, parsed.Address, s.Address)
+		}
+
+		if parsed.Command != s.Command {
+			t.Errorf("%s: command = %#x, want %#x", p, parsed.Command, s.Command)
+		}
+	}
+}
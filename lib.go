@@ -16,7 +16,9 @@ package flipperirfile
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -72,137 +74,52 @@ type Signal struct {
 	Data      []int
 }
 
-func Unmarshal(s []byte) (*SignalLib, error) { //nolint:cyclop
-	lib := SignalLib{}
-	signals := make([]Signal, 0, 10)
-
-	var curr Signal
-
-	lines := bytes.Split(s, []byte("\n"))
-
-	for lineno, line := range lines {
-		line = bytes.TrimSpace(line)
-
-		if len(line) == 0 {
-			continue
-		}
-
-		if lib.Filetype == "" && bytes.HasPrefix(line, []byte("Filetype:")) {
-			lib.Filetype = Filetype(bytes.TrimSpace(bytes.TrimPrefix(line, []byte("Filetype:"))))
-			continue
-		}
-
-		if lib.Version == "" && bytes.HasPrefix(line, []byte("Version:")) {
-			lib.Version = string(bytes.TrimSpace(bytes.TrimPrefix(line, []byte("Version:"))))
-			continue
+// Unmarshal parses a whole `.ir` file into a SignalLib. For large files,
+// NewDecoder lets callers read signals one at a time instead. Passing
+// WithValidation also runs Validate on the result.
+func Unmarshal(s []byte, opts ...DecoderOption) (*SignalLib, error) {
+	dec := NewDecoder(bytes.NewReader(s), opts...)
+	lib := SignalLib{Signals: make([]Signal, 0, 10)}
+
+	for {
+		sig, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
 		}
 
-		if bytes.Equal(line, []byte("#")) {
-			if curr.Name != "" {
-				signals = append(signals, curr)
-				curr = Signal{}
-			}
-
-			continue
+		if err != nil {
+			return nil, err
 		}
 
-		parts := bytes.SplitN(line, []byte(":"), 2)
+		lib.Signals = append(lib.Signals, *sig)
+	}
 
-		if len(parts) != 2 {
-			continue
-		}
+	lib.Filetype = dec.Filetype()
+	lib.Version = dec.Version()
 
-		key := string(bytes.TrimSpace(parts[0]))
-		value := string(bytes.TrimSpace(parts[1]))
-
-		switch key {
-		case "name":
-			curr.Name = value
-		case "type":
-			curr.Type = SignalType(value)
-		case "protocol":
-			curr.Protocol = value
-		case "address":
-			addr, err := leHexToUint32(value)
-			if err != nil {
-				return nil, fmt.Errorf("invalid address at line %d: %v", lineno, err)
-			}
-
-			curr.Address = addr
-		case "command":
-			cmd, err := leHexToUint32(value)
-			if err != nil {
-				return nil, fmt.Errorf("invalid command at line %d: %v", lineno, err)
-			}
-
-			curr.Command = cmd
-		case "frequency":
-			freq, err := strconv.Atoi(value)
-			if err != nil {
-				return nil, fmt.Errorf("invalid frequency at line %d: %v", lineno, err)
-			}
-
-			curr.Frequency = freq
-		case "duty_cycle":
-			duty, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				return nil, fmt.Errorf("invalid duty_cycle at line %d: %v", lineno, err)
-			}
-
-			curr.DutyCycle = duty
-		case "data":
-			fields := strings.Fields(value)
-			ints := make([]int, len(fields))
-
-			for i, f := range fields {
-				n, err := strconv.Atoi(f)
-				if err != nil {
-					return nil, fmt.Errorf("invalid data int at line %d: %v", lineno, err)
-				}
-
-				ints[i] = n
-			}
-
-			curr.Data = ints
+	if dec.validate {
+		if err := lib.Validate(); err != nil {
+			return nil, err
 		}
 	}
 
-	if curr.Name != "" {
-		signals = append(signals, curr)
-	}
-
-	lib.Signals = signals
-
 	return &lib, nil
 }
 
+// Marshal serializes a whole SignalLib into `.ir` file contents. For large
+// libraries, NewEncoder lets callers write signals one at a time instead.
 func Marshal(l *SignalLib) ([]byte, error) {
 	var buf bytes.Buffer
 
-	_, _ = fmt.Fprintf(&buf, "Filetype: %s\n", l.Filetype)
-	_, _ = fmt.Fprintf(&buf, "Version: %s\n", l.Version)
+	enc := NewEncoder(&buf)
 
-	for _, s := range l.Signals {
-		buf.WriteString("#\n")
-
-		_, _ = fmt.Fprintf(&buf, "name: %s\n", s.Name)
-		_, _ = fmt.Fprintf(&buf, "type: %s\n", s.Type)
-
-		switch s.Type {
-		case SignalTypeParsed:
-			_, _ = fmt.Fprintf(&buf, "protocol: %s\n", s.Protocol)
-			_, _ = fmt.Fprintf(&buf, "address: %s\n", encodeLEUint32Hex(s.Address))
-			_, _ = fmt.Fprintf(&buf, "command: %s\n", encodeLEUint32Hex(s.Command))
-		case SignalTypeRaw:
-			_, _ = fmt.Fprintf(&buf, "frequency: %d\n", s.Frequency)
-			_, _ = fmt.Fprintf(&buf, "duty_cycle: %.6f\n", s.DutyCycle)
-			buf.WriteString("data:")
-
-			for _, v := range s.Data {
-				_, _ = fmt.Fprintf(&buf, " %d", v)
-			}
+	if err := enc.WriteHeader(l.Filetype, l.Version); err != nil {
+		return nil, err
+	}
 
-			buf.WriteString("\n")
+	for i := range l.Signals {
+		if err := enc.Encode(&l.Signals[i]); err != nil {
+			return nil, err
 		}
 	}
 
@@ -0,0 +1,244 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// prontoCarrierUnit is the fixed conversion factor Pronto Hex uses to turn
+// a carrier frequency in Hz into the 4-digit hex word stored at word 1.
+const prontoCarrierUnit = 0.241246
+
+// prontoDefaultDutyCycle is assumed for raw signals produced by
+// UnmarshalPronto, since Pronto Hex has no concept of duty cycle.
+const prontoDefaultDutyCycle = 0.33
+
+// MarshalPronto encodes s as a Pronto Hex (CCF) string: a learned-code
+// ("0000") format word, the carrier frequency word, the once/repeat pair
+// counts, and the mark/space pairs themselves in carrier-cycle units.
+//
+// Parsed signals are first synthesized into a raw pulse train using the
+// protocol's known bit timings (see timingFor); raw signals are encoded
+// as-is. The whole pulse train is emitted as the "once" section, with an
+// empty repeat section, since Signal has no notion of a separate repeat
+// frame.
+func MarshalPronto(s *Signal) (string, error) {
+	data, freq, err := prontoRawPulses(s)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) == 0 || len(data)%2 != 0 {
+		return "", fmt.Errorf("flipperirfile: pronto encode: data must hold an even number of mark/space pulses")
+	}
+
+	if freq <= 0 {
+		return "", fmt.Errorf("flipperirfile: pronto encode: invalid carrier frequency %d", freq)
+	}
+
+	periodUs := 1e6 / float64(freq)
+	carrierCode := int(math.Round(1e6 / (float64(freq) * prontoCarrierUnit)))
+	pairs := len(data) / 2
+
+	words := make([]string, 0, 4+len(data))
+	words = append(words,
+		"0000",
+		fmt.Sprintf("%04X", carrierCode),
+		fmt.Sprintf("%04X", pairs),
+		"0000",
+	)
+
+	for _, us := range data {
+		cycles := int(math.Round(float64(us) / periodUs))
+		words = append(words, fmt.Sprintf("%04X", cycles))
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// UnmarshalPronto parses a Pronto Hex (CCF) string into a raw Signal. Only
+// the "0000" (learned/raw) format is supported, since there's no built-in
+// table of pre-programmed Pronto device codes to resolve the others
+// against.
+func UnmarshalPronto(s string) (*Signal, error) {
+	fields := strings.Fields(s)
+
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("flipperirfile: pronto decode: expected at least 4 words, got %d", len(fields))
+	}
+
+	words := make([]int, len(fields))
+
+	for i, f := range fields {
+		v, err := strconv.ParseUint(f, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("flipperirfile: pronto decode: invalid hex word %q: %w", f, err)
+		}
+
+		words[i] = int(v)
+	}
+
+	if words[0] != 0 {
+		return nil, fmt.Errorf("flipperirfile: pronto decode: unsupported format code %04X, only learned codes (0000) are supported", words[0])
+	}
+
+	carrierCode := words[1]
+	if carrierCode <= 0 {
+		return nil, fmt.Errorf("flipperirfile: pronto decode: invalid carrier code %04X", carrierCode)
+	}
+
+	freq := int(math.Round(1e6 / (float64(carrierCode) * prontoCarrierUnit)))
+	preamblePairs, repeatPairs := words[2], words[3]
+	totalPairs := preamblePairs + repeatPairs
+
+	if len(words)-4 != totalPairs*2 {
+		return nil, fmt.Errorf(
+			"flipperirfile: pronto decode: expected %d pulses for %d preamble + %d repeat pairs, got %d",
+			totalPairs*2, preamblePairs, repeatPairs, len(words)-4,
+		)
+	}
+
+	periodUs := 1e6 / float64(freq)
+	data := make([]int, 0, totalPairs*2)
+
+	for _, cycles := range words[4:] {
+		data = append(data, int(math.Round(float64(cycles)*periodUs)))
+	}
+
+	return &Signal{
+		Type:      SignalTypeRaw,
+		Frequency: freq,
+		DutyCycle: prontoDefaultDutyCycle,
+		Data:      data,
+	}, nil
+}
+
+// MarshalLibPronto encodes every signal in lib as a Pronto Hex string, in
+// order, mirroring how Marshal relates to MarshalPronto one level down.
+func MarshalLibPronto(lib *SignalLib) ([]string, error) {
+	codes := make([]string, len(lib.Signals))
+
+	for i := range lib.Signals {
+		code, err := MarshalPronto(&lib.Signals[i])
+		if err != nil {
+			return nil, fmt.Errorf("flipperirfile: pronto encode: signal %q: %w", lib.Signals[i].Name, err)
+		}
+
+		codes[i] = code
+	}
+
+	return codes, nil
+}
+
+// UnmarshalLibPronto decodes a batch of Pronto Hex strings, in order, into
+// a SignalLib of the given filetype and version. Pronto Hex carries no
+// signal name, so each signal is named by its position in codes.
+func UnmarshalLibPronto(filetype Filetype, version string, codes []string) (*SignalLib, error) {
+	signals := make([]Signal, len(codes))
+
+	for i, code := range codes {
+		sig, err := UnmarshalPronto(code)
+		if err != nil {
+			return nil, fmt.Errorf("flipperirfile: pronto decode: code %d: %w", i, err)
+		}
+
+		sig.Name = fmt.Sprintf("signal %d", i+1)
+		signals[i] = *sig
+	}
+
+	return &SignalLib{Filetype: filetype, Version: version, Signals: signals}, nil
+}
+
+// prontoRawPulses returns the mark/space microsecond pulse train and
+// carrier frequency backing s, synthesizing it from the protocol's known
+// bit timings for a parsed signal.
+func prontoRawPulses(s *Signal) ([]int, int, error) {
+	switch s.Type {
+	case SignalTypeRaw:
+		return s.Data, s.Frequency, nil
+	case SignalTypeParsed:
+		raw, err := s.ToRaw()
+		if err != nil {
+			return nil, 0, fmt.Errorf("flipperirfile: pronto encode: %w", err)
+		}
+
+		return raw.Data, raw.Frequency, nil
+	default:
+		return nil, 0, fmt.Errorf("flipperirfile: pronto encode: unsupported signal type %q", s.Type)
+	}
+}
+
+// interFrameGap is the idle gap paired with the final stop mark of every
+// synthesized pulse train, whether or not it ends with a repeat frame.
+const interFrameGap = 10000
+
+// synthesizePulses renders s's address and command into a mark/space pulse
+// train per the protocol timing t, including the leader burst, the bitwise
+// complement of each field for protocols that send one, a stop mark, and a
+// repeat frame for protocols that define a RepeatSpace of their own.
+func synthesizePulses(s *Signal, t protocolTiming) []int {
+	pulses := make([]int, 0, 2*(8*(t.AddressBytes+t.CommandBytes)*2+4))
+
+	if t.LeaderMark > 0 {
+		pulses = append(pulses, t.LeaderMark, t.LeaderSpace)
+	}
+
+	appendField := func(v uint32, bytes int) {
+		bits := bytes * 8
+		appendBits(&pulses, t, v, bits)
+
+		if t.Inverted {
+			appendBits(&pulses, t, ^v, bits)
+		}
+	}
+
+	appendField(s.Address, t.AddressBytes)
+	appendField(s.Command, t.CommandBytes)
+
+	if t.RepeatSpace == 0 {
+		pulses = append(pulses, t.BitMark, interFrameGap)
+
+		return pulses
+	}
+
+	// Protocols that define a repeat gap transmit it as: stop mark, the
+	// repeat gap, the leader burst again, and a final stop mark - e.g. how
+	// a held button repeats an NEC frame.
+	pulses = append(pulses, t.BitMark, t.RepeatSpace, t.LeaderMark, t.LeaderSpace, t.BitMark, interFrameGap)
+
+	return pulses
+}
+
+// appendBits encodes the low `bits` bits of v as BitMark/ZeroSpace or
+// BitMark/OneSpace pulse pairs, in the bit order the protocol transmits.
+func appendBits(pulses *[]int, t protocolTiming, v uint32, bits int) {
+	for i := 0; i < bits; i++ {
+		idx := i
+		if !t.LSBFirst {
+			idx = bits - 1 - i
+		}
+
+		space := t.ZeroSpace
+		if v>>idx&1 == 1 {
+			space = t.OneSpace
+		}
+
+		*pulses = append(*pulses, t.BitMark, space)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import "testing"
+
+func TestMarshalProntoParsedSignal(t *testing.T) {
+	s := &Signal{
+		Name:     "Power",
+		Type:     SignalTypeParsed,
+		Protocol: string(ProtocolNEC),
+		Address:  0x04,
+		Command:  0x08,
+	}
+
+	pronto, err := MarshalPronto(s)
+	if err != nil {
+		t.Fatalf("MarshalPronto(%+v) returned error: %v", s, err)
+	}
+
+	raw, err := UnmarshalPronto(pronto)
+	if err != nil {
+		t.Fatalf("UnmarshalPronto(%q) returned error: %v", pronto, err)
+	}
+
+	if err := raw.Validate(); err != nil {
+		t.Fatalf("round-tripped raw signal failed Validate: %v", err)
+	}
+
+	want, err := s.ToRaw()
+	if err != nil {
+		t.Fatalf("ToRaw(%+v) returned error: %v", s, err)
+	}
+
+	// Pronto Hex quantizes the carrier into a 4-digit hex code, so the
+	// round-tripped frequency only has to be close, not exact.
+	if delta := raw.Frequency - want.Frequency; delta < -100 || delta > 100 {
+		t.Errorf("frequency = %d, want ~%d", raw.Frequency, want.Frequency)
+	}
+
+	if len(raw.Data) != len(want.Data) {
+		t.Fatalf("data length = %d, want %d", len(raw.Data), len(want.Data))
+	}
+}
+
+func TestMarshalUnmarshalLibPronto(t *testing.T) {
+	lib := &SignalLib{
+		Filetype: FiletypeSignalLib,
+		Version:  "1",
+		Signals: []Signal{
+			{Name: "Power", Type: SignalTypeParsed, Protocol: string(ProtocolNEC), Address: 0x04, Command: 0x08},
+			{Name: "Mute", Type: SignalTypeRaw, Frequency: 38000, DutyCycle: 0.33, Data: []int{9000, 4500, 560, 560}},
+		},
+	}
+
+	codes, err := MarshalLibPronto(lib)
+	if err != nil {
+		t.Fatalf("MarshalLibPronto returned error: %v", err)
+	}
+
+	if len(codes) != len(lib.Signals) {
+		t.Fatalf("got %d codes, want %d", len(codes), len(lib.Signals))
+	}
+
+	got, err := UnmarshalLibPronto(lib.Filetype, lib.Version, codes)
+	if err != nil {
+		t.Fatalf("UnmarshalLibPronto returned error: %v", err)
+	}
+
+	if len(got.Signals) != len(lib.Signals) {
+		t.Fatalf("got %d signals, want %d", len(got.Signals), len(lib.Signals))
+	}
+
+	for i := range got.Signals {
+		if err := got.Signals[i].Validate(); err != nil {
+			t.Errorf("signal %d failed Validate: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+// protocolTiming describes the carrier and pulse-distance characteristics
+// used to synthesize (or decode) the raw pulse train of a parsed signal.
+// All durations are microseconds, matching the units used in Signal.Data.
+type protocolTiming struct {
+	Carrier     int
+	DutyCycle   float64
+	LeaderMark  int
+	LeaderSpace int
+	BitMark     int
+	ZeroSpace   int
+	OneSpace    int
+
+	LSBFirst bool
+
+	AddressBytes int
+	CommandBytes int
+	Inverted     bool // address/command is immediately followed by its bitwise complement
+
+	RepeatSpace int // space before a repeat frame's leader mark, 0 if the protocol has none
+}
+
+// protocolTimings holds the published bit timings for the protocols Flipper
+// Zero ships support for out of the box.
+var protocolTimings = map[Protocol]protocolTiming{
+	ProtocolNEC: {
+		Carrier: 38000, DutyCycle: 0.33,
+		LeaderMark: 9000, LeaderSpace: 4500,
+		BitMark: 560, ZeroSpace: 560, OneSpace: 1690,
+		LSBFirst:     true,
+		AddressBytes: 1, CommandBytes: 1, Inverted: true,
+		RepeatSpace: 2250,
+	},
+	ProtocolNEC42: {
+		Carrier: 38000, DutyCycle: 0.33,
+		LeaderMark: 9000, LeaderSpace: 4500,
+		BitMark: 560, ZeroSpace: 560, OneSpace: 1690,
+		LSBFirst:     true,
+		AddressBytes: 2, CommandBytes: 1, Inverted: true,
+		RepeatSpace: 2250,
+	},
+	ProtocolNECExt: {
+		Carrier: 38000, DutyCycle: 0.33,
+		LeaderMark: 9000, LeaderSpace: 4500,
+		BitMark: 560, ZeroSpace: 560, OneSpace: 1690,
+		LSBFirst:     true,
+		AddressBytes: 2, CommandBytes: 2, Inverted: true,
+		RepeatSpace: 2250,
+	},
+	ProtocolSamsung32: {
+		Carrier: 38000, DutyCycle: 0.33,
+		LeaderMark: 4500, LeaderSpace: 4500,
+		BitMark: 560, ZeroSpace: 560, OneSpace: 1690,
+		LSBFirst:     true,
+		AddressBytes: 2, CommandBytes: 2, Inverted: false,
+		RepeatSpace: 2250,
+	},
+	ProtocolRC5: {
+		Carrier: 36000, DutyCycle: 0.25,
+		LeaderMark: 0, LeaderSpace: 0,
+		BitMark: 889, ZeroSpace: 889, OneSpace: 1778,
+		LSBFirst:     false,
+		AddressBytes: 1, CommandBytes: 1, Inverted: false,
+	},
+	ProtocolRC5X: {
+		Carrier: 36000, DutyCycle: 0.25,
+		LeaderMark: 0, LeaderSpace: 0,
+		BitMark: 889, ZeroSpace: 889, OneSpace: 1778,
+		LSBFirst:     false,
+		AddressBytes: 1, CommandBytes: 2, Inverted: false, // extra data byte vs. RC5
+	},
+	ProtocolRC6: {
+		Carrier: 36000, DutyCycle: 0.33,
+		LeaderMark: 2666, LeaderSpace: 889,
+		BitMark: 444, ZeroSpace: 444, OneSpace: 888,
+		LSBFirst:     false,
+		AddressBytes: 1, CommandBytes: 1, Inverted: false,
+	},
+	ProtocolSIRC: {
+		Carrier: 40000, DutyCycle: 0.33,
+		LeaderMark: 2400, LeaderSpace: 600,
+		BitMark: 600, ZeroSpace: 600, OneSpace: 1200,
+		LSBFirst:     true,
+		AddressBytes: 1, CommandBytes: 1, Inverted: false,
+		RepeatSpace: 600,
+	},
+	ProtocolRCA: {
+		Carrier: 56000, DutyCycle: 0.33,
+		LeaderMark: 4000, LeaderSpace: 4000,
+		BitMark: 500, ZeroSpace: 1000, OneSpace: 2000,
+		LSBFirst:     true,
+		AddressBytes: 1, CommandBytes: 1, Inverted: true,
+		RepeatSpace: 4000,
+	},
+	ProtocolPioneer: {
+		Carrier: 40000, DutyCycle: 0.33,
+		LeaderMark: 13500, LeaderSpace: 4250,
+		BitMark: 500, ZeroSpace: 500, OneSpace: 1500,
+		LSBFirst:     true,
+		AddressBytes: 2, CommandBytes: 2, Inverted: true,
+		RepeatSpace: 25000,
+	},
+	ProtocolKaseikyo: {
+		Carrier: 37000, DutyCycle: 0.33,
+		LeaderMark: 3400, LeaderSpace: 1700,
+		BitMark: 430, ZeroSpace: 430, OneSpace: 1290,
+		LSBFirst:     true,
+		AddressBytes: 2, CommandBytes: 2, Inverted: false,
+		RepeatSpace: 0,
+	},
+}
+
+// timingFor returns the known timing table for p, and false if p isn't one
+// of the protocols this package has built-in support for.
+func timingFor(p Protocol) (protocolTiming, bool) {
+	t, ok := protocolTimings[p]
+	return t, ok
+}
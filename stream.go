@@ -0,0 +1,266 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineSize bounds the bufio.Scanner buffer used by Decoder. It's sized
+// well above what even a dense raw `data:` line needs, since universal
+// remote libraries can carry very long pulse trains.
+const maxLineSize = 1 << 20 // 1 MiB
+
+// Decoder reads signals one at a time from an `.ir` stream, without
+// holding the whole file in memory. The header (Filetype/Version) is
+// consumed by the first call to Decode.
+type Decoder struct {
+	scanner    *bufio.Scanner
+	lineno     int
+	blockStart int
+	filetype   Filetype
+	version    string
+	curr       Signal
+	validate   bool
+}
+
+// DecoderOption configures a Decoder or Unmarshal call.
+type DecoderOption func(*Decoder)
+
+// WithValidation makes Decode (and, transitively, Unmarshal) reject any
+// signal that fails Signal.Validate.
+func WithValidation() DecoderOption {
+	return func(d *Decoder) {
+		d.validate = true
+	}
+}
+
+// NewDecoder returns a Decoder that reads signals from r.
+func NewDecoder(r io.Reader, opts ...DecoderOption) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+
+	d := &Decoder{scanner: scanner}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Filetype returns the stream's Filetype header. It's only valid after
+// the first call to Decode.
+func (d *Decoder) Filetype() Filetype {
+	return d.filetype
+}
+
+// Version returns the stream's Version header. It's only valid after the
+// first call to Decode.
+func (d *Decoder) Version() string {
+	return d.version
+}
+
+// Decode reads and returns the next signal from the stream. It returns
+// io.EOF once the stream is exhausted.
+func (d *Decoder) Decode() (*Signal, error) { //nolint:cyclop
+	for d.scanner.Scan() {
+		d.lineno++
+		line := strings.TrimSpace(d.scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		if d.filetype == "" && strings.HasPrefix(line, "Filetype:") {
+			d.filetype = Filetype(strings.TrimSpace(strings.TrimPrefix(line, "Filetype:")))
+			continue
+		}
+
+		if d.version == "" && strings.HasPrefix(line, "Version:") {
+			d.version = strings.TrimSpace(strings.TrimPrefix(line, "Version:"))
+			continue
+		}
+
+		if line == "#" {
+			if d.curr.Name == "" {
+				d.blockStart = d.lineno
+				continue
+			}
+
+			sig := d.curr
+			d.curr = Signal{}
+
+			res, err := d.finish(&sig)
+			// This `#` also opens the next signal's block.
+			d.blockStart = d.lineno
+
+			return res, err
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		if err := d.setField(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("flipperirfile: decode: %w", err)
+	}
+
+	if d.curr.Name != "" {
+		sig := d.curr
+		d.curr = Signal{}
+
+		return d.finish(&sig)
+	}
+
+	return nil, io.EOF
+}
+
+// finish runs sig through Signal.Validate when the decoder was built with
+// WithValidation, wrapping any failure as a ParseError naming the line the
+// signal's `#` block started at.
+func (d *Decoder) finish(sig *Signal) (*Signal, error) {
+	if !d.validate {
+		return sig, nil
+	}
+
+	if err := sig.Validate(); err != nil {
+		return nil, &ParseError{Line: d.blockStart, Field: "signal", Err: err}
+	}
+
+	return sig, nil
+}
+
+func (d *Decoder) setField(key, value string) error {
+	switch key {
+	case "name":
+		d.curr.Name = value
+	case "type":
+		d.curr.Type = SignalType(value)
+	case "protocol":
+		d.curr.Protocol = value
+	case "address":
+		addr, err := leHexToUint32(value)
+		if err != nil {
+			return &ParseError{Line: d.lineno, Field: key, Err: err}
+		}
+
+		d.curr.Address = addr
+	case "command":
+		cmd, err := leHexToUint32(value)
+		if err != nil {
+			return &ParseError{Line: d.lineno, Field: key, Err: err}
+		}
+
+		d.curr.Command = cmd
+	case "frequency":
+		freq, err := strconv.Atoi(value)
+		if err != nil {
+			return &ParseError{Line: d.lineno, Field: key, Err: err}
+		}
+
+		d.curr.Frequency = freq
+	case "duty_cycle":
+		duty, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return &ParseError{Line: d.lineno, Field: key, Err: err}
+		}
+
+		d.curr.DutyCycle = duty
+	case "data":
+		fields := strings.Fields(value)
+		ints := make([]int, len(fields))
+
+		for i, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return &ParseError{Line: d.lineno, Field: key, Err: err}
+			}
+
+			ints[i] = n
+		}
+
+		d.curr.Data = ints
+	}
+
+	return nil
+}
+
+// Encoder writes signals one at a time to an `.ir` stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// WriteHeader writes the stream's Filetype and Version header. It must be
+// called exactly once, before the first call to Encode.
+func (e *Encoder) WriteHeader(filetype Filetype, version string) error {
+	_, err := fmt.Fprintf(e.w, "Filetype: %s\nVersion: %s\n", filetype, version)
+	if err != nil {
+		return fmt.Errorf("flipperirfile: encode: %w", err)
+	}
+
+	return nil
+}
+
+// Encode writes s to the stream.
+func (e *Encoder) Encode(s *Signal) error {
+	if _, err := io.WriteString(e.w, "#\n"); err != nil {
+		return fmt.Errorf("flipperirfile: encode: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(e.w, "name: %s\ntype: %s\n", s.Name, s.Type); err != nil {
+		return fmt.Errorf("flipperirfile: encode: %w", err)
+	}
+
+	switch s.Type {
+	case SignalTypeParsed:
+		_, err := fmt.Fprintf(e.w, "protocol: %s\naddress: %s\ncommand: %s\n",
+			s.Protocol, encodeLEUint32Hex(s.Address), encodeLEUint32Hex(s.Command))
+		if err != nil {
+			return fmt.Errorf("flipperirfile: encode: %w", err)
+		}
+	case SignalTypeRaw:
+		if _, err := fmt.Fprintf(e.w, "frequency: %d\nduty_cycle: %.6f\ndata:", s.Frequency, s.DutyCycle); err != nil {
+			return fmt.Errorf("flipperirfile: encode: %w", err)
+		}
+
+		for _, v := range s.Data {
+			if _, err := fmt.Fprintf(e.w, " %d", v); err != nil {
+				return fmt.Errorf("flipperirfile: encode: %w", err)
+			}
+		}
+
+		if _, err := io.WriteString(e.w, "\n"); err != nil {
+			return fmt.Errorf("flipperirfile: encode: %w", err)
+		}
+	}
+
+	return nil
+}
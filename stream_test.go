@@ -0,0 +1,65 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMalformedFieldReturnsParseError(t *testing.T) {
+	input := "Filetype: IR signals file\nVersion: 1\n#\nname: Power\ntype: parsed\naddress: not hex\n"
+
+	_, err := NewDecoder(strings.NewReader(input)).Decode()
+	if err == nil {
+		t.Fatal("Decode returned no error for a malformed address line")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Decode error %v is not a *ParseError", err)
+	}
+
+	if parseErr.Field != "address" {
+		t.Errorf("Field = %q, want %q", parseErr.Field, "address")
+	}
+}
+
+func TestDecodeWithValidationReportsBlockStartLine(t *testing.T) {
+	input := "Filetype: IR signals file\n" + // line 1
+		"Version: 1\n" + // line 2
+		"#\n" + // line 3 - signal block starts here
+		"name: Power\n" + // line 4
+		"type: parsed\n" + // line 5
+		"protocol: Bogus\n" + // line 6
+		"address: 00 00 00 00\n" + // line 7
+		"command: 00 00 00 00\n" + // line 8
+		"#\n" // line 9 - block closes here
+
+	_, err := NewDecoder(strings.NewReader(input), WithValidation()).Decode()
+	if err == nil {
+		t.Fatal("Decode returned no error for a signal with an unknown protocol")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Decode error %v is not a *ParseError", err)
+	}
+
+	if parseErr.Line != 3 {
+		t.Errorf("Line = %d, want 3 (the signal's `#` block start, not %d where the block closed)", parseErr.Line, 9)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrBadAddressWidth is returned when a parsed signal's Address or
+	// Command doesn't fit in the bit width its Protocol defines.
+	ErrBadAddressWidth = errors.New("flipperirfile: address/command doesn't fit the protocol's bit width")
+
+	// ErrBadRawTiming is returned when a raw signal's Frequency, DutyCycle
+	// or Data is outside the range a real IR transmitter can produce.
+	ErrBadRawTiming = errors.New("flipperirfile: implausible raw signal timing")
+)
+
+// minFrequency and maxFrequency bound the carrier frequencies real IR
+// transmitters and receivers are built for.
+const (
+	minFrequency = 20000
+	maxFrequency = 60000
+)
+
+// ParseError reports a problem decoding a specific line of an `.ir` file,
+// naming the field that failed so callers can surface file/line context.
+type ParseError struct {
+	Line  int
+	Field string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("flipperirfile: line %d: %s: %v", e.Line, e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that l has a recognized Filetype, a non-empty Version,
+// and that every signal it holds also passes Signal.Validate.
+func (l *SignalLib) Validate() error {
+	switch l.Filetype {
+	case FiletypeSignalLib, FiletypeSignalFile:
+	default:
+		return fmt.Errorf("flipperirfile: unknown filetype %q", l.Filetype)
+	}
+
+	if l.Version == "" {
+		return errors.New("flipperirfile: version is required")
+	}
+
+	for i := range l.Signals {
+		if err := l.Signals[i].Validate(); err != nil {
+			return fmt.Errorf("flipperirfile: signal %q: %w", l.Signals[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that s is internally consistent: a parsed signal names a
+// known Protocol whose Address and Command fit its bit width, and a raw
+// signal has a plausible Frequency, DutyCycle and Data.
+func (s *Signal) Validate() error { //nolint:cyclop
+	switch s.Type {
+	case SignalTypeParsed:
+		t, ok := timingFor(Protocol(s.Protocol))
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownProtocol, s.Protocol)
+		}
+
+		if s.Address > mask(t.AddressBytes*8) {
+			return fmt.Errorf("%w: address %#x exceeds %d bytes", ErrBadAddressWidth, s.Address, t.AddressBytes)
+		}
+
+		if s.Command > mask(t.CommandBytes*8) {
+			return fmt.Errorf("%w: command %#x exceeds %d bytes", ErrBadAddressWidth, s.Command, t.CommandBytes)
+		}
+	case SignalTypeRaw:
+		if s.Frequency < minFrequency || s.Frequency > maxFrequency {
+			return fmt.Errorf("%w: frequency %d Hz outside %d-%d", ErrBadRawTiming, s.Frequency, minFrequency, maxFrequency)
+		}
+
+		if s.DutyCycle <= 0 || s.DutyCycle > 1 {
+			return fmt.Errorf("%w: duty_cycle %f outside (0, 1]", ErrBadRawTiming, s.DutyCycle)
+		}
+
+		if len(s.Data) == 0 || len(s.Data)%2 != 0 {
+			return fmt.Errorf("%w: data is empty or has an odd number of pulses", ErrBadRawTiming)
+		}
+
+		for _, v := range s.Data {
+			if v <= 0 {
+				return fmt.Errorf("%w: data pulse %d isn't positive", ErrBadRawTiming, v)
+			}
+		}
+	default:
+		return fmt.Errorf("flipperirfile: unknown signal type %q", s.Type)
+	}
+
+	return nil
+}
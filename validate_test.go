@@ -0,0 +1,129 @@
+// Copyright 2025 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flipperirfile
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSignalValidateRejectsUnknownProtocol(t *testing.T) {
+	s := &Signal{Type: SignalTypeParsed, Protocol: "Bogus"}
+
+	err := s.Validate()
+	if !errors.Is(err, ErrUnknownProtocol) {
+		t.Fatalf("Validate() = %v, want ErrUnknownProtocol", err)
+	}
+}
+
+func TestSignalValidateRejectsOverWidthAddress(t *testing.T) {
+	s := &Signal{Type: SignalTypeParsed, Protocol: string(ProtocolNEC), Address: 0x100, Command: 1}
+
+	err := s.Validate()
+	if !errors.Is(err, ErrBadAddressWidth) {
+		t.Fatalf("Validate() = %v, want ErrBadAddressWidth", err)
+	}
+}
+
+func TestSignalValidateRejectsOverWidthCommand(t *testing.T) {
+	s := &Signal{Type: SignalTypeParsed, Protocol: string(ProtocolNEC), Address: 1, Command: 0x100}
+
+	err := s.Validate()
+	if !errors.Is(err, ErrBadAddressWidth) {
+		t.Fatalf("Validate() = %v, want ErrBadAddressWidth", err)
+	}
+}
+
+func TestSignalValidateRejectsBadRawTiming(t *testing.T) {
+	tests := map[string]Signal{
+		"frequency too low":  {Type: SignalTypeRaw, Frequency: 100, DutyCycle: 0.33, Data: []int{1, 1}},
+		"frequency too high": {Type: SignalTypeRaw, Frequency: 100000, DutyCycle: 0.33, Data: []int{1, 1}},
+		"duty cycle zero":    {Type: SignalTypeRaw, Frequency: 38000, DutyCycle: 0, Data: []int{1, 1}},
+		"duty cycle over 1":  {Type: SignalTypeRaw, Frequency: 38000, DutyCycle: 1.5, Data: []int{1, 1}},
+		"odd-length data":    {Type: SignalTypeRaw, Frequency: 38000, DutyCycle: 0.33, Data: []int{1, 1, 1}},
+		"negative data":      {Type: SignalTypeRaw, Frequency: 38000, DutyCycle: 0.33, Data: []int{1, -1}},
+	}
+
+	for name, s := range tests {
+		s := s
+
+		t.Run(name, func(t *testing.T) {
+			err := s.Validate()
+			if !errors.Is(err, ErrBadRawTiming) {
+				t.Fatalf("Validate() = %v, want ErrBadRawTiming", err)
+			}
+		})
+	}
+}
+
+func TestSignalLibValidateRejectsUnknownFiletype(t *testing.T) {
+	lib := &SignalLib{Filetype: "not a real filetype", Version: "1"}
+
+	if err := lib.Validate(); err == nil {
+		t.Fatal("Validate() = nil, want an error for an unrecognized Filetype")
+	}
+}
+
+func TestSignalLibValidateRejectsEmptyVersion(t *testing.T) {
+	lib := &SignalLib{Filetype: FiletypeSignalLib, Version: ""}
+
+	if err := lib.Validate(); err == nil {
+		t.Fatal("Validate() = nil, want an error for an empty Version")
+	}
+}
+
+func TestSignalLibValidateRejectsBadSignal(t *testing.T) {
+	lib := &SignalLib{
+		Filetype: FiletypeSignalLib,
+		Version:  "1",
+		Signals:  []Signal{{Name: "bad", Type: SignalTypeParsed, Protocol: "Bogus"}},
+	}
+
+	err := lib.Validate()
+	if !errors.Is(err, ErrUnknownProtocol) {
+		t.Fatalf("Validate() = %v, want ErrUnknownProtocol", err)
+	}
+}
+
+func TestUnmarshalWithValidationRejectsBadSignal(t *testing.T) {
+	input := "Filetype: IR signals file\nVersion: 1\n#\nname: Power\ntype: parsed\nprotocol: Bogus\naddress: 00 00 00 00\ncommand: 00 00 00 00\n"
+
+	_, err := Unmarshal([]byte(input), WithValidation())
+	if err == nil {
+		t.Fatal("Unmarshal returned no error for a signal with an unknown protocol")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Unmarshal error %v is not a *ParseError", err)
+	}
+
+	if !errors.Is(err, ErrUnknownProtocol) {
+		t.Fatalf("Unmarshal error %v doesn't wrap ErrUnknownProtocol", err)
+	}
+}
+
+func TestUnmarshalWithoutValidationAcceptsBadSignal(t *testing.T) {
+	input := "Filetype: IR signals file\nVersion: 1\n#\nname: Power\ntype: parsed\nprotocol: Bogus\naddress: 00 00 00 00\ncommand: 00 00 00 00\n"
+
+	lib, err := Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if len(lib.Signals) != 1 {
+		t.Fatalf("got %d signals, want 1", len(lib.Signals))
+	}
+}